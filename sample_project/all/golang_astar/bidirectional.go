@@ -0,0 +1,215 @@
+package golang_astar
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// BidirectionalNode extends Node with a From method reporting the edges
+// leading into the node, so that a backward search can be run from the end
+// node symmetrically with the forward search that Route runs from start.
+type BidirectionalNode interface {
+	Node
+	From() []Edge
+}
+
+// biRNode is the bidirectional analogue of rNode.  Forward and backward
+// searches each keep their own set of biRNodes.
+type biRNode struct {
+	n    BidirectionalNode
+	from BidirectionalNode
+	l    int
+	g    Cost
+	f    Cost
+	fx   int
+}
+
+type biHeap []*biRNode
+
+func (h biHeap) Len() int           { return len(h) }
+func (h biHeap) Less(i, j int) bool { return h[i].f < h[j].f }
+func (h biHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].fx = i
+	h[j].fx = j
+}
+
+func (p *biHeap) Push(x interface{}) {
+	h := *p
+	fx := len(h)
+	h = append(h, x.(*biRNode))
+	h[fx].fx = fx
+	*p = h
+}
+
+func (p *biHeap) Pop() interface{} {
+	h := *p
+	last := len(h) - 1
+	*p = h[:last]
+	h[last].fx = -1
+	return h[last]
+}
+
+// biFrontier is one direction's open heap, reached set, and settled set.
+// A node is settled once it has been popped off oh, at which point its g
+// is final; reached alone isn't enough to trust a node's g; as long as it
+// is still sitting in oh, a cheaper route to it may yet be found.
+type biFrontier struct {
+	oh      biHeap
+	reached map[BidirectionalNode]*biRNode
+	settled map[BidirectionalNode]bool
+}
+
+// RouteBidirectional computes a route from start to end by running two A*
+// searches concurrently, one forward from start using To and one backward
+// from end using From.  Each node a direction pops is checked against the
+// other direction's reached set to find meeting points, and the search
+// stops once the f values at the tops of the two heaps sum to at least the
+// cheapest meeting cost found so far.
+//
+// The heuristic is evaluated the same way Route evaluates it: the target
+// of each direction's search is asked to estimate the cost from a
+// candidate node.  For the combined search to remain optimal, the forward
+// and backward heuristics should be consistent with each other; callers
+// with inconsistent heuristics can average them, e.g.
+// hf(n) = (h(n, end) - h(n, start)) / 2, to keep the combined potential
+// monotone.
+func RouteBidirectional(start, end BidirectionalNode) (route []Node, cost int) {
+	var mu sync.Mutex
+
+	fwd := &biFrontier{reached: map[BidirectionalNode]*biRNode{}, settled: map[BidirectionalNode]bool{}}
+	fc := &biRNode{n: start, l: 1, f: Cost(end.Heuristic(start))}
+	fwd.reached[start] = fc
+	fwd.oh = biHeap{fc}
+
+	bwd := &biFrontier{reached: map[BidirectionalNode]*biRNode{}, settled: map[BidirectionalNode]bool{}}
+	bc := &biRNode{n: end, l: 1, f: Cost(start.Heuristic(end))}
+	bwd.reached[end] = bc
+	bwd.oh = biHeap{bc}
+
+	var best Cost = -1
+	var meeting BidirectionalNode
+	done := make(chan struct{})
+	var stopOnce sync.Once
+	stop := func() { stopOnce.Do(func() { close(done) }) }
+
+	// step is a candidate expansion computed for one edge, ready to be
+	// committed into self's heap and reached map.
+	type step struct {
+		to   BidirectionalNode
+		g, h Cost
+	}
+
+	// expand runs one direction's best-first loop.  mu is held for the
+	// whole of each node's expansion -- the pop and meeting check, the
+	// walk over n's edges and the commit of new g/h values -- so the
+	// other direction never sees n popped while its successors are not
+	// yet on the heap, which would let it stop too early.  The two
+	// directions interleave node by node rather than overlapping.
+	expand := func(self, other *biFrontier, forward bool) {
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			mu.Lock()
+			if len(self.oh) == 0 {
+				mu.Unlock()
+				stop()
+				return
+			}
+			if best >= 0 && len(other.oh) > 0 && self.oh[0].f+other.oh[0].f >= best {
+				mu.Unlock()
+				stop()
+				return
+			}
+			br := heap.Pop(&self.oh).(*biRNode)
+			n := br.n
+			self.settled[n] = true
+			// Any node other has reached is a meeting point, even if other
+			// has not settled it yet: its g there is the cost of a real
+			// path, so best stays an upper bound, and the cheapest path's
+			// meeting point is always reached by one side when the other
+			// pops it.
+			if alt, ok := other.reached[n]; ok {
+				if g := br.g + alt.g; best < 0 || g < best {
+					best = g
+					meeting = n
+				}
+			}
+
+			var edges []Edge
+			if forward {
+				edges = n.To()
+			} else {
+				edges = n.From()
+			}
+			l := br.l + 1
+			steps := make([]step, 0, len(edges))
+			for _, e := range edges {
+				to, ok := e.To.(BidirectionalNode)
+				if !ok {
+					continue
+				}
+				g := br.g + e.Cost
+				var h Cost
+				if forward {
+					h = Cost(end.Heuristic(to))
+				} else {
+					h = Cost(start.Heuristic(to))
+				}
+				steps = append(steps, step{to, g, h})
+			}
+
+			for _, s := range steps {
+				if alt, ok := self.reached[s.to]; !ok {
+					alt = &biRNode{n: s.to, from: n, l: l, g: s.g, f: s.g + s.h}
+					self.reached[s.to] = alt
+					heap.Push(&self.oh, alt)
+				} else if s.g < alt.g {
+					alt.from = n
+					alt.l = l
+					alt.g = s.g
+					alt.f = s.g + s.h
+					if alt.fx < 0 {
+						heap.Push(&self.oh, alt)
+					} else {
+						heap.Fix(&self.oh, alt.fx)
+					}
+				}
+			}
+			mu.Unlock()
+		}
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); expand(fwd, bwd, true) }()
+	go func() { defer wg.Done(); expand(bwd, fwd, false) }()
+	wg.Wait()
+
+	if meeting == nil {
+		return nil, 0
+	}
+
+	fn := fwd.reached[meeting]
+	bn := bwd.reached[meeting]
+	cost = int(fn.g + bn.g)
+
+	route = []Node{}
+	for r := fn; ; r = fwd.reached[r.from] {
+		route = append([]Node{r.n}, route...)
+		if r.from == nil {
+			break
+		}
+	}
+	for r := bwd.reached[bn.from]; r != nil; r = bwd.reached[r.from] {
+		route = append(route, r.n)
+		if r.from == nil {
+			break
+		}
+	}
+	return route, cost
+}
@@ -0,0 +1,59 @@
+package golang_astar
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRouteBidirectional(t *testing.T) {
+	s := newTestNode("S")
+	a := newTestNode("A")
+	b := newTestNode("B")
+	end := newTestNode("T")
+	connect(s, a, 1)
+	connect(s, b, 5)
+	connect(a, end, 1)
+	connect(b, end, 1)
+
+	route, cost := RouteBidirectional(s, end)
+	if cost != 2 {
+		t.Fatalf("cost = %d, want 2", cost)
+	}
+	if got := namesOf(route); len(got) != 3 || got[0] != "S" || got[2] != "T" {
+		t.Fatalf("route = %v, want [S A T]", got)
+	}
+}
+
+// TestRouteBidirectionalMatchesRouteUnderConcurrency runs RouteBidirectional
+// many times on a graph with several hubs whose forward+backward totals land
+// close together, so that the many ways the two search goroutines can be
+// scheduled are exercised; a search that stops early or misses the cheapest
+// meeting point surfaces as a cost or route differing from Route's.
+func TestRouteBidirectionalMatchesRouteUnderConcurrency(t *testing.T) {
+	s := newTestNode("S")
+	end := newTestNode("T")
+	fromStart := []Cost{3, 1, 4, 1, 5, 9, 2, 6}
+	toEnd := []Cost{1, 5, 1, 4, 1, 1, 3, 1}
+	for i := range fromStart {
+		hub := newTestNode(fmt.Sprintf("M%d", i))
+		connect(s, hub, fromStart[i])
+		connect(hub, end, toEnd[i])
+	}
+
+	want, wantCost := Route(s, end)
+	if wantCost != 4 {
+		t.Fatalf("test setup: Route cost = %d, want 4", wantCost)
+	}
+
+	const trials = 3000
+	for i := 0; i < trials; i++ {
+		route, cost := RouteBidirectional(s, end)
+		if cost != wantCost {
+			t.Fatalf("trial %d: RouteBidirectional cost = %d, want %d (Route found %v)",
+				i, cost, wantCost, namesOf(want))
+		}
+		if got := namesOf(route); len(got) != 3 || got[1] != "M0" {
+			t.Fatalf("trial %d: route = %v, want via M0", i, got)
+		}
+	}
+}
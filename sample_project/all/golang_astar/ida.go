@@ -0,0 +1,54 @@
+package golang_astar
+
+// RouteIDA computes a route from start to end using IDA* (iterative
+// deepening A*): a depth-first search bounded by an f-cost threshold,
+// retried with the threshold raised to the smallest f-cost that exceeded
+// the previous bound whenever a search comes up empty.  Unlike Route, it
+// uses only O(d) memory -- a recursion stack plus a visited set for the
+// current path -- at the cost of re-exploring nodes across iterations.
+// maxCost bounds how far the threshold may grow before RouteIDA gives up.
+func RouteIDA(start, end Node, maxCost Cost) (route []Node, cost int) {
+	threshold := Cost(end.Heuristic(start))
+	for threshold <= maxCost {
+		path := []Node{start}
+		visited := map[Node]bool{start: true}
+		found, next, result := idaSearch(start, end, 0, threshold, path, visited)
+		if found {
+			return result, int(next)
+		}
+		if next < 0 {
+			return nil, 0
+		}
+		threshold = next
+	}
+	return nil, 0
+}
+
+// idaSearch explores n's subtree depth-first, returning whether end was
+// found, the f-cost that should become the next threshold when it wasn't,
+// and (when found) the completed path.
+func idaSearch(n, end Node, g, threshold Cost, path []Node, visited map[Node]bool) (found bool, next Cost, result []Node) {
+	f := g + Cost(end.Heuristic(n))
+	if f > threshold {
+		return false, f, nil
+	}
+	if n == end {
+		return true, f, path
+	}
+	min := Cost(-1)
+	for _, e := range n.To() {
+		if visited[e.To] {
+			continue
+		}
+		visited[e.To] = true
+		path = append(path, e.To)
+		if found, next, result := idaSearch(e.To, end, g+e.Cost, threshold, path, visited); found {
+			return true, next, result
+		} else if next >= 0 && (min < 0 || next < min) {
+			min = next
+		}
+		path = path[:len(path)-1]
+		delete(visited, e.To)
+	}
+	return false, min, nil
+}
@@ -0,0 +1,33 @@
+package golang_astar
+
+import "testing"
+
+func TestRouteIDA(t *testing.T) {
+	s := newTestNode("S")
+	a := newTestNode("A")
+	b := newTestNode("B")
+	end := newTestNode("T")
+	connect(s, a, 1)
+	connect(s, b, 5)
+	connect(a, end, 1)
+	connect(b, end, 1)
+
+	route, cost := RouteIDA(s, end, 10)
+	if cost != 2 {
+		t.Fatalf("cost = %d, want 2", cost)
+	}
+	want := []string{"S", "A", "T"}
+	if got := namesOf(route); !equalStrings(got, want) {
+		t.Fatalf("route = %v, want %v", got, want)
+	}
+}
+
+func TestRouteIDAMaxCostExceeded(t *testing.T) {
+	s := newTestNode("S")
+	end := newTestNode("T")
+	connect(s, end, 10)
+
+	if route, _ := RouteIDA(s, end, 5); route != nil {
+		t.Fatalf("route = %v, want nil for a cost above maxCost", route)
+	}
+}
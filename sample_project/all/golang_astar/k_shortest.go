@@ -0,0 +1,184 @@
+package golang_astar
+
+import "container/heap"
+
+// MaskedNode wraps a Node, filtering the edges To returns through a
+// caller-supplied predicate without mutating the underlying graph.  It is
+// used by RouteK to forbid specific edges while searching for alternative
+// paths; every node it reaches is wrapped with the same mask, so filtering
+// applies to the whole subgraph reachable from it, not just its immediate
+// neighbors.
+type MaskedNode struct {
+	Node
+	allow *func(from Node, e Edge) bool
+}
+
+// To returns only the edges of the wrapped node that satisfy the mask's
+// predicate, with their destinations wrapped in the same mask.  The
+// predicate is given the wrapped node itself as "from", so it can tell a
+// forbidden edge apart from an edge with the same destination leaving a
+// different node.
+func (m MaskedNode) To() []Edge {
+	edges := m.Node.To()
+	out := make([]Edge, 0, len(edges))
+	for _, e := range edges {
+		if !(*m.allow)(m.Node, e) {
+			continue
+		}
+		e.To = MaskedNode{Node: e.To, allow: m.allow}
+		out = append(out, e)
+	}
+	return out
+}
+
+// unmask strips any MaskedNode wrapper a masked search may have returned.
+func unmask(n Node) Node {
+	for {
+		m, ok := n.(MaskedNode)
+		if !ok {
+			return n
+		}
+		n = m.Node
+	}
+}
+
+// kCandidate is a not-yet-emitted path waiting in RouteK's candidate heap.
+type kCandidate struct {
+	path []Node
+	cost int
+}
+
+type candidateHeap []*kCandidate
+
+func (h candidateHeap) Len() int            { return len(h) }
+func (h candidateHeap) Less(i, j int) bool  { return h[i].cost < h[j].cost }
+func (h candidateHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (p *candidateHeap) Push(x interface{}) { *p = append(*p, x.(*kCandidate)) }
+func (p *candidateHeap) Pop() interface{} {
+	h := *p
+	last := len(h) - 1
+	x := h[last]
+	*p = h[:last]
+	return x
+}
+
+// pathCost sums the edge costs along path by re-walking each node's edges,
+// since a path is just a slice of nodes with no cost of its own.  A node
+// can have more than one edge to the same next hop -- e.g. two call sites
+// from the same caller to the same callee with different weights -- so
+// each hop takes the cheapest matching edge, matching the cost Route
+// itself would have found for that hop.
+func pathCost(path []Node) int {
+	var c Cost
+	for i := 0; i+1 < len(path); i++ {
+		best := Cost(-1)
+		for _, e := range path[i].To() {
+			if unmask(e.To) == path[i+1] && (best < 0 || e.Cost < best) {
+				best = e.Cost
+			}
+		}
+		if best >= 0 {
+			c += best
+		}
+	}
+	return int(c)
+}
+
+func samePath(a, b []Node) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// RouteK returns up to k loopless shortest paths from start to end in
+// increasing cost order, built on top of Route using Yen's algorithm: seed
+// the result set with Route's shortest path, then for each already-found
+// path try every node in it as a spur, mask off the edges that would
+// repeat an already-found prefix, and route from the spur to end on the
+// masked graph. The cheapest unseen candidate becomes the next path, and
+// the process repeats until k paths are found or candidates run dry.
+func RouteK(start, end Node, k int) (paths [][]Node, costs []int) {
+	p1, c1 := Route(start, end)
+	if p1 == nil {
+		return nil, nil
+	}
+	paths = [][]Node{p1}
+	costs = []int{c1}
+
+	var ch candidateHeap
+
+	for len(paths) < k {
+		prev := paths[len(paths)-1]
+		for i := 0; i < len(prev)-1; i++ {
+			spurNode := prev[i]
+			rootPath := prev[:i+1]
+
+			// forbidden holds the specific (spurNode, next-hop) edges used
+			// by already-found paths sharing this prefix -- not every
+			// edge into those next hops, which would also block
+			// legitimate alternate routes through other nodes.
+			forbidden := map[Node]bool{}
+			for _, p := range paths {
+				if len(p) > i && samePath(p[:i+1], rootPath) {
+					forbidden[p[i+1]] = true
+				}
+			}
+			removed := map[Node]bool{}
+			for _, n := range rootPath[:len(rootPath)-1] {
+				removed[n] = true
+			}
+			allow := func(from Node, e Edge) bool {
+				to := unmask(e.To)
+				if unmask(from) == spurNode && forbidden[to] {
+					return false
+				}
+				return !removed[to]
+			}
+
+			// end must be wrapped in the same mask as spurNode: Route's
+			// bestNode == end check compares concrete types, and a
+			// MaskedNode is never == an unwrapped Node even when it
+			// wraps the same underlying node.
+			maskedEnd := MaskedNode{Node: end, allow: &allow}
+			spurPath, _ := Route(MaskedNode{Node: spurNode, allow: &allow}, maskedEnd)
+			if spurPath == nil {
+				continue
+			}
+
+			total := append([]Node{}, rootPath[:len(rootPath)-1]...)
+			for _, n := range spurPath {
+				total = append(total, unmask(n))
+			}
+
+			known := false
+			for _, p := range paths {
+				if samePath(p, total) {
+					known = true
+					break
+				}
+			}
+			for _, c := range ch {
+				if samePath(c.path, total) {
+					known = true
+					break
+				}
+			}
+			if !known {
+				heap.Push(&ch, &kCandidate{path: total, cost: pathCost(total)})
+			}
+		}
+		if ch.Len() == 0 {
+			break
+		}
+		next := heap.Pop(&ch).(*kCandidate)
+		paths = append(paths, next.path)
+		costs = append(costs, next.cost)
+	}
+	return paths, costs
+}
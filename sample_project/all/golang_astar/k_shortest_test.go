@@ -0,0 +1,56 @@
+package golang_astar
+
+import "testing"
+
+func TestRouteKFindsAlternatePaths(t *testing.T) {
+	s := newTestNode("S")
+	a := newTestNode("A")
+	b := newTestNode("B")
+	end := newTestNode("T")
+	connect(s, a, 1)
+	connect(s, b, 2)
+	connect(a, end, 1)
+	connect(b, end, 1)
+
+	paths, costs := RouteK(s, end, 2)
+	if len(paths) != 2 {
+		t.Fatalf("len(paths) = %d, want 2", len(paths))
+	}
+	if costs[0] != 2 || costs[1] != 3 {
+		t.Fatalf("costs = %v, want [2 3]", costs)
+	}
+	if got := namesOf(paths[0]); !equalStrings(got, []string{"S", "A", "T"}) {
+		t.Fatalf("paths[0] = %v, want [S A T]", got)
+	}
+	if got := namesOf(paths[1]); !equalStrings(got, []string{"S", "B", "T"}) {
+		t.Fatalf("paths[1] = %v, want [S B T]", got)
+	}
+}
+
+// TestRouteKCostWithParallelEdges guards pathCost against picking the
+// first matching edge for a hop instead of the cheapest: B has two edges
+// to T, a cheap one added after an expensive one, so a first-match
+// pathCost would report the second path's cost as 12 instead of its true
+// minimum-edge cost of 3.
+func TestRouteKCostWithParallelEdges(t *testing.T) {
+	s := newTestNode("S")
+	a := newTestNode("A")
+	b := newTestNode("B")
+	end := newTestNode("T")
+	connect(s, a, 1)
+	connect(a, end, 1)
+	connect(s, b, 2)
+	connect(b, end, 10) // expensive edge added first
+	connect(b, end, 1)  // cheap parallel edge added second
+
+	paths, costs := RouteK(s, end, 2)
+	if len(paths) != 2 {
+		t.Fatalf("len(paths) = %d, want 2", len(paths))
+	}
+	if got := namesOf(paths[1]); !equalStrings(got, []string{"S", "B", "T"}) {
+		t.Fatalf("paths[1] = %v, want [S B T]", got)
+	}
+	if costs[1] != 3 {
+		t.Fatalf("costs[1] = %d, want 3 (S->B cost 2 + the cheaper B->T edge cost 1)", costs[1])
+	}
+}
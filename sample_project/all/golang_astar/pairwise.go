@@ -0,0 +1,122 @@
+package golang_astar
+
+import "container/heap"
+
+// PairCoster is implemented by nodes whose traversal cost depends on the
+// transition between two consecutive edges rather than on a single edge
+// weight, e.g. turn penalties or fee structures that fold an inbound and
+// an outbound leg into one non-negative charge.  When the current node
+// implements PairCoster, RoutePairwise asks it for the cost of moving from
+// prev through cur to next instead of using the edge's own Cost.
+type PairCoster interface {
+	Node
+	PairCost(prev, cur, next Node) Cost
+}
+
+// pairRNode is the pairwise analogue of rNode.  Because the reached set is
+// keyed on (node, predecessor) rather than on node alone, predecessors are
+// linked directly rather than looked back up by Node.
+type pairRNode struct {
+	n    Node
+	from *pairRNode
+	l    int
+	g    Cost
+	f    Cost
+	fx   int
+}
+
+// pairKey identifies a reached state by the node it represents and the
+// predecessor it was reached from, so the same vertex can be revisited via
+// a different predecessor if that yields a better total cost.  Keying on
+// the predecessor node rather than the incoming Edge value matters: two
+// different predecessors can produce edges with an identical (To, Cost),
+// and each predecessor can still lead to a different PairCost on the next
+// hop.
+type pairKey struct {
+	n    Node
+	from Node
+}
+
+type pairHeap []*pairRNode
+
+func (h pairHeap) Len() int           { return len(h) }
+func (h pairHeap) Less(i, j int) bool { return h[i].f < h[j].f }
+func (h pairHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].fx = i
+	h[j].fx = j
+}
+
+func (p *pairHeap) Push(x interface{}) {
+	h := *p
+	fx := len(h)
+	h = append(h, x.(*pairRNode))
+	h[fx].fx = fx
+	*p = h
+}
+
+func (p *pairHeap) Pop() interface{} {
+	h := *p
+	last := len(h) - 1
+	*p = h[:last]
+	h[last].fx = -1
+	return h[last]
+}
+
+// RoutePairwise computes a route from start to end like Route, except that
+// a node implementing PairCoster charges for the transition between its
+// incoming and outgoing edges rather than a flat edge weight.  The reached
+// set is keyed on (node, predecessor) pairs instead of node alone, so the
+// same vertex can be reached again through a different predecessor if that
+// yields a better total cost -- as is needed to encode turn penalties or
+// context-sensitive edge weights where per-edge additivity breaks down.
+func RoutePairwise(start, end Node) (route []Node, cost int) {
+	cr := &pairRNode{n: start, l: 1, f: Cost(end.Heuristic(start))}
+	reached := map[pairKey]*pairRNode{{n: start}: cr}
+	oh := pairHeap{cr}
+	for len(oh) > 0 {
+		best := heap.Pop(&oh).(*pairRNode)
+		if best.n == end {
+			cost = int(best.g)
+			route = make([]Node, best.l)
+			for i := len(route) - 1; i >= 0; i-- {
+				route[i] = best.n
+				best = best.from
+			}
+			return
+		}
+		l := best.l + 1
+		for _, e := range best.n.To() {
+			stepCost := e.Cost
+			if pc, ok := best.n.(PairCoster); ok {
+				var prev Node
+				if best.from != nil {
+					prev = best.from.n
+				}
+				stepCost = pc.PairCost(prev, best.n, e.To)
+			}
+			g := best.g + stepCost
+			key := pairKey{n: e.To, from: best.n}
+			if alt, ok := reached[key]; !ok {
+				alt = &pairRNode{n: e.To, from: best, l: l,
+					g: g, f: g + Cost(end.Heuristic(e.To))}
+				reached[key] = alt
+				heap.Push(&oh, alt)
+			} else {
+				if g >= alt.g {
+					continue // candidate route no better than existing route
+				}
+				alt.from = best
+				alt.l = l
+				alt.g = g
+				alt.f = g + Cost(end.Heuristic(e.To))
+				if alt.fx < 0 {
+					heap.Push(&oh, alt)
+				} else {
+					heap.Fix(&oh, alt.fx)
+				}
+			}
+		}
+	}
+	return nil, 0
+}
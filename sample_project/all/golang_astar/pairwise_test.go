@@ -0,0 +1,54 @@
+package golang_astar
+
+import "testing"
+
+// TestRoutePairwiseDistinguishesPredecessors builds a graph where two
+// predecessors reach M via edges with an identical (To, Cost) value but
+// a much higher g for one of them, while M's PairCost makes the
+// higher-g arrival the one that leads to the cheaper overall route. A
+// reached-map keyed on the incoming edge's value rather than on the
+// predecessor node collapses the two arrivals into one and silently
+// keeps the cheaper-g (but ultimately worse) route.
+func TestRoutePairwiseDistinguishesPredecessors(t *testing.T) {
+	s := newTestNode("S")
+	p1 := newTestNode("P1")
+	p2 := newTestNode("P2")
+	m := newTestNode("M")
+	n := newTestNode("N")
+	end := newTestNode("T")
+
+	connect(s, p1, 1)
+	connect(s, p2, 5)
+	connect(p1, m, 1)
+	connect(p2, m, 1) // identical (To, Cost) to p1->m
+	connect(m, n, 1)
+	connect(n, end, 1)
+
+	m.pair = func(prev, _, _ Node) Cost {
+		if prev == Node(p1) {
+			return 100
+		}
+		return 1
+	}
+
+	route, cost := RoutePairwise(s, end)
+	if cost != 8 {
+		t.Fatalf("cost = %d, want 8 (via P2, whose higher g pays off through a cheaper PairCost)", cost)
+	}
+	want := []string{"S", "P2", "M", "N", "T"}
+	if got := namesOf(route); !equalStrings(got, want) {
+		t.Fatalf("route = %v, want %v", got, want)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,107 @@
+package golang_astar
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// RouteOptions configures a Searcher.
+type RouteOptions struct {
+	// ExpectedNodes hints at how many nodes a search is likely to touch,
+	// so the reached map and openHeap backing slice can be preallocated
+	// instead of growing incrementally.
+	ExpectedNodes int
+}
+
+// Searcher runs repeated Route-style searches while reusing the rNodes,
+// reached map and openHeap backing slice across calls via sync.Pool,
+// cutting the GC pressure that comes from allocating all of that fresh on
+// every call.  Construct one with NewSearcher and keep it alive for the
+// lifetime of a workload that issues many searches.
+type Searcher struct {
+	opts     RouteOptions
+	nodePool sync.Pool
+	mapPool  sync.Pool
+	heapPool sync.Pool
+}
+
+// NewSearcher returns a Searcher configured with opts.
+func NewSearcher(opts RouteOptions) *Searcher {
+	s := &Searcher{opts: opts}
+	s.nodePool.New = func() interface{} { return new(rNode) }
+	s.mapPool.New = func() interface{} {
+		return make(map[Node]*rNode, opts.ExpectedNodes)
+	}
+	s.heapPool.New = func() interface{} {
+		return make(openHeap, 0, opts.ExpectedNodes)
+	}
+	return s
+}
+
+// Route computes a route from start to end exactly as the package-level
+// Route does, but draws its rNodes, reached map and openHeap from s's
+// pools instead of allocating them fresh, and returns them via Reset once
+// the search completes.
+func (s *Searcher) Route(start, end Node) (route []Node, cost int) {
+	r := s.mapPool.Get().(map[Node]*rNode)
+	oh := s.heapPool.Get().(openHeap)[:0]
+	defer func() { s.Reset(r, oh) }()
+
+	cr := s.nodePool.Get().(*rNode)
+	*cr = rNode{n: start, l: 1, f: Cost(end.Heuristic(start))}
+	r[start] = cr
+	oh = append(oh, cr)
+	cr.fx = 0
+
+	for len(oh) > 0 {
+		bestRoute := heap.Pop(&oh).(*rNode)
+		bestNode := bestRoute.n
+		if bestNode == end {
+			cost = int(bestRoute.g)
+			route = make([]Node, bestRoute.l)
+			for i := len(route) - 1; i >= 0; i-- {
+				route[i] = bestRoute.n
+				bestRoute = r[bestRoute.from]
+			}
+			return
+		}
+		l := bestRoute.l + 1
+		for _, to := range bestNode.To() {
+			g := bestRoute.g + to.Cost
+			if alt, ok := r[to.To]; !ok {
+				alt = s.nodePool.Get().(*rNode)
+				*alt = rNode{n: to.To, from: bestNode, l: l,
+					g: g, f: g + Cost(end.Heuristic(to.To))}
+				r[to.To] = alt
+				heap.Push(&oh, alt)
+			} else {
+				if g >= alt.g {
+					continue // candidate route no better than existing route
+				}
+				alt.from = bestNode
+				alt.l = l
+				alt.g = g
+				alt.f = g + Cost(end.Heuristic(alt.n))
+				if alt.fx < 0 {
+					heap.Push(&oh, alt)
+				} else {
+					heap.Fix(&oh, alt.fx)
+				}
+			}
+		}
+	}
+	return nil, 0
+}
+
+// Reset zeros the rNodes touched by a search and returns r and oh to s's
+// pools, making them available to the next Route call.  Route calls Reset
+// itself when it returns.
+func (s *Searcher) Reset(r map[Node]*rNode, oh openHeap) {
+	for k, n := range r {
+		*n = rNode{}
+		s.nodePool.Put(n)
+		delete(r, k)
+	}
+	s.mapPool.Put(r)
+	s.heapPool.Put(oh[:0])
+}
@@ -0,0 +1,28 @@
+package golang_astar
+
+import "testing"
+
+func TestSearcherRoute(t *testing.T) {
+	s := newTestNode("S")
+	a := newTestNode("A")
+	b := newTestNode("B")
+	end := newTestNode("T")
+	connect(s, a, 1)
+	connect(s, b, 5)
+	connect(a, end, 1)
+	connect(b, end, 1)
+
+	searcher := NewSearcher(RouteOptions{ExpectedNodes: 4})
+
+	// Route twice on the same Searcher to exercise pool reuse across calls.
+	for i := 0; i < 2; i++ {
+		route, cost := searcher.Route(s, end)
+		if cost != 2 {
+			t.Fatalf("call %d: cost = %d, want 2", i, cost)
+		}
+		want := []string{"S", "A", "T"}
+		if got := namesOf(route); !equalStrings(got, want) {
+			t.Fatalf("call %d: route = %v, want %v", i, got, want)
+		}
+	}
+}
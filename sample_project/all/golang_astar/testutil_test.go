@@ -0,0 +1,59 @@
+package golang_astar
+
+// testNode is a minimal graph vertex used across this package's tests. It
+// implements Node, BidirectionalNode and PairCoster so the same graphs can
+// exercise Route, RouteBidirectional, RoutePairwise, the pooled Searcher,
+// RouteIDA and RouteK.  Its heuristic is always zero, which is trivially
+// admissible, so tests don't need to hand-compute real distances.
+type testNode struct {
+	name string
+	to   []Edge
+	from []Edge
+	pair func(prev, cur, next Node) Cost
+}
+
+// newTestNode returns a named, unconnected testNode.  Its default PairCost
+// just looks up the cheapest matching outgoing edge's Cost, so graphs built
+// for other tests behave under RoutePairwise exactly as they do under Route;
+// tests that care about turn penalties override pair directly.
+func newTestNode(name string) *testNode {
+	n := &testNode{name: name}
+	n.pair = func(_, _, next Node) Cost {
+		best := Cost(-1)
+		for _, e := range n.to {
+			if e.To == next && (best < 0 || e.Cost < best) {
+				best = e.Cost
+			}
+		}
+		if best < 0 {
+			return 0
+		}
+		return best
+	}
+	return n
+}
+
+func (n *testNode) To() []Edge          { return n.to }
+func (n *testNode) From() []Edge        { return n.from }
+func (n *testNode) Heuristic(Node) Cost { return 0 }
+
+func (n *testNode) PairCost(prev, cur, next Node) Cost {
+	return n.pair(prev, cur, next)
+}
+
+// connect adds a directed a->b edge of the given cost, recording the
+// reverse edge too so graphs built with it can be searched with
+// RouteBidirectional's From as well as the usual To.
+func connect(a, b *testNode, cost Cost) {
+	a.to = append(a.to, Edge{To: b, Cost: cost})
+	b.from = append(b.from, Edge{To: a, Cost: cost})
+}
+
+// namesOf returns the names of a route's nodes, for readable assertions.
+func namesOf(route []Node) []string {
+	names := make([]string, len(route))
+	for i, n := range route {
+		names[i] = n.(*testNode).name
+	}
+	return names
+}